@@ -0,0 +1,101 @@
+package crawler
+
+import (
+	"context"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/chromedp/cdproto/fetch"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// defaultBlockedResourceTypes are blocked by WithResourceBlocking when the
+// caller doesn't pass its own list: images, fonts, and media are pure
+// bandwidth/time cost on the article list page and never affect whether we
+// can find new posts.
+var defaultBlockedResourceTypes = []network.ResourceType{
+	network.ResourceTypeImage,
+	network.ResourceTypeFont,
+	network.ResourceTypeMedia,
+}
+
+// defaultBlockedDomains are the hostnames (tracking pixels, ad/analytics
+// networks) WithResourceBlocking fails regardless of resource type when
+// BlockedDomains hasn't been configured.
+var defaultBlockedDomains = []string{
+	"googletagmanager.com",
+	"google-analytics.com",
+	"doubleclick.net",
+	"cnzz.com",
+	"umeng.com",
+}
+
+// BlockedDomains is populated from config/param.toml ([crawler]
+// blocked_domains) so operators can extend the ad/tracking blocklist
+// without a code change. Empty until main sets it, in which case
+// WithResourceBlocking falls back to defaultBlockedDomains.
+var BlockedDomains []string
+
+// WithResourceBlocking enables fetch interception on ctx and fails any
+// request whose resource type is in types (defaulting to
+// defaultBlockedResourceTypes when none are given) or whose URL matches
+// BlockedDomains (falling back to defaultBlockedDomains when unconfigured),
+// instead of letting chromedp download it. Call this once per browser
+// context, before any navigation.
+func WithResourceBlocking(ctx context.Context, types ...network.ResourceType) error {
+	if len(types) == 0 {
+		types = defaultBlockedResourceTypes
+	}
+	blocked := make(map[network.ResourceType]bool, len(types))
+	for _, t := range types {
+		blocked[t] = true
+	}
+
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		e, ok := ev.(*fetch.EventRequestPaused)
+		if !ok {
+			return
+		}
+
+		go func() {
+			if blocked[e.ResourceType] || matchesBlockedDomain(e.Request.URL) {
+				if err := chromedp.Run(ctx, fetch.FailRequest(e.RequestID, network.ErrorReasonBlockedByClient)); err != nil {
+					log.Printf("⚠️ 拦截请求失败: %v", err)
+				}
+				return
+			}
+
+			if err := chromedp.Run(ctx, fetch.ContinueRequest(e.RequestID)); err != nil {
+				log.Printf("⚠️ 放行请求失败: %v", err)
+			}
+		}()
+	})
+
+	return chromedp.Run(ctx, fetch.Enable().WithPatterns([]*fetch.RequestPattern{{URLPattern: "*"}}))
+}
+
+// matchesBlockedDomain reports whether rawURL's host matches, or is a
+// subdomain of, an entry in BlockedDomains (or defaultBlockedDomains if
+// BlockedDomains hasn't been configured).
+func matchesBlockedDomain(rawURL string) bool {
+	domains := BlockedDomains
+	if len(domains) == 0 {
+		domains = defaultBlockedDomains
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+
+	for _, domain := range domains {
+		domain = strings.ToLower(domain)
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
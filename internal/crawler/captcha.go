@@ -0,0 +1,75 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/chromedp"
+)
+
+// captchaSelectors lists the DOM nodes DJI's login flow injects when it
+// decides to challenge the session with a slider or image captcha instead
+// of letting the typing-simulation flow through.
+var captchaSelectors = []string{".geetest_panel", ".nc_wrapper"}
+
+// CaptchaError is returned by Login when a captcha/slider challenge appears
+// that the typing-simulation flow cannot solve on its own. Selector is
+// whichever known captcha node was found, for use with WaitForHumanUnlock.
+type CaptchaError struct {
+	Selector string
+}
+
+func (e *CaptchaError) Error() string {
+	return fmt.Sprintf("检测到验证码 (%s)，需要人工介入", e.Selector)
+}
+
+// detectCaptcha does a quick, non-blocking check for any known captcha
+// selector and returns the one found, if any.
+func detectCaptcha(ctx context.Context) (string, bool) {
+	checkCtx, cancel := context.WithTimeout(ctx, 1*time.Second)
+	defer cancel()
+
+	for _, selector := range captchaSelectors {
+		var nodes []*cdp.Node
+		if err := chromedp.Run(checkCtx, chromedp.Nodes(selector, &nodes, chromedp.ByQuery, chromedp.AtLeast(0))); err == nil && len(nodes) > 0 {
+			return selector, true
+		}
+	}
+	return "", false
+}
+
+// WaitForHumanUnlock polls ctx for selector to disappear from the page,
+// giving a human operator up to timeout to solve the captcha in the
+// now-visible browser window. On success it immediately persists the fresh
+// cookies via saveCookies so later headless runs can skip login for as
+// long as possible.
+func WaitForHumanUnlock(ctx context.Context, selector string, timeout time.Duration) error {
+	const pollInterval = 3 * time.Second
+	deadline := time.Now().Add(timeout)
+
+	log.Printf("🙋 等待人工处理验证码 (%s)，最长等待 %v...", selector, timeout)
+
+	for time.Now().Before(deadline) {
+		var nodes []*cdp.Node
+		if err := chromedp.Run(ctx, chromedp.Nodes(selector, &nodes, chromedp.ByQuery, chromedp.AtLeast(0))); err != nil {
+			return fmt.Errorf("检查验证码状态失败: %w", err)
+		}
+
+		if len(nodes) == 0 {
+			log.Println("✅ 验证码已由人工解决")
+			if err := saveCookies(ctx); err != nil {
+				log.Printf("⚠️ 保存 Cookies 失败: %v", err)
+			} else {
+				log.Println("💾 Cookies 已保存")
+			}
+			return nil
+		}
+
+		time.Sleep(pollInterval)
+	}
+
+	return fmt.Errorf("等待人工解决验证码超时 (%v)", timeout)
+}
@@ -0,0 +1,129 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/chromedp/chromedp"
+
+	"robomaster-monitor/internal/storage"
+)
+
+const (
+	detailPoolSize   = 4 // 并发打开的详情页标签数
+	detailTabTimeout = 30 * time.Second
+)
+
+// FetchArticleDetails opens each candidate article in its own chromedp tab,
+// sharing the logged-in session from parent, and extracts the body text,
+// images, tags, and engagement counters. Up to detailPoolSize tabs run
+// concurrently via a semaphore; a failure on one article only logs a
+// warning and does not abort the others.
+func FetchArticleDetails(parent context.Context, articles []storage.Article) {
+	sem := make(chan struct{}, detailPoolSize)
+	var wg sync.WaitGroup
+
+	for _, article := range articles {
+		article := article
+		sem <- struct{}{}
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := fetchArticleDetail(parent, article); err != nil {
+				log.Printf("⚠️ 获取文章详情失败 (%s): %v", article.Title, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// fetchArticleDetail spawns a sibling tab off parent via chromedp.NewContext
+// (inheriting the parent allocator's logged-in session), navigates to the
+// article, and persists the extracted content.
+func fetchArticleDetail(parent context.Context, article storage.Article) error {
+	tabCtx, cancel := chromedp.NewContext(parent)
+	defer cancel()
+
+	tabCtx, cancel = context.WithTimeout(tabCtx, detailTabTimeout)
+	defer cancel()
+
+	var htmlContent string
+	err := chromedp.Run(tabCtx,
+		chromedp.Navigate(article.URL),
+		chromedp.WaitReady("body"),
+		randomDelay(500*time.Millisecond, 1*time.Second),
+		chromedp.OuterHTML("html", &htmlContent),
+	)
+	if err != nil {
+		return fmt.Errorf("打开文章页失败: %w", err)
+	}
+
+	detail, err := parseArticleDetail(article.ID, htmlContent)
+	if err != nil {
+		return fmt.Errorf("解析文章详情失败: %w", err)
+	}
+
+	return storage.SaveArticleDetail(detail)
+}
+
+// parseArticleDetail extracts the body, images, tags, and counters from a
+// rendered article page.
+func parseArticleDetail(articleID int64, htmlContent string) (*storage.ArticleDetail, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil, fmt.Errorf("解析HTML失败: %w", err)
+	}
+
+	body := strings.TrimSpace(doc.Find(".articleDetail__content").Text())
+
+	var images []string
+	doc.Find(".articleDetail__content img").Each(func(i int, s *goquery.Selection) {
+		if src, ok := s.Attr("src"); ok {
+			images = append(images, src)
+		}
+	})
+
+	var tags []string
+	doc.Find(".articleDetail__tags .tagItem").Each(func(i int, s *goquery.Selection) {
+		tags = append(tags, strings.TrimSpace(s.Text()))
+	})
+
+	return &storage.ArticleDetail{
+		ArticleID:    articleID,
+		Body:         body,
+		Images:       images,
+		Tags:         tags,
+		ViewCount:    parseLeadingInt(doc.Find(".articleDetail__views").Text()),
+		ReplyCount:   parseLeadingInt(doc.Find(".articleDetail__replies").Text()),
+		LastEditedAt: strings.TrimSpace(doc.Find(".articleDetail__editedTime").Text()),
+	}, nil
+}
+
+// parseLeadingInt extracts the leading run of digits from a counter string
+// such as "1234 次浏览", returning 0 if none is found.
+func parseLeadingInt(raw string) int {
+	raw = strings.TrimSpace(raw)
+	var digits strings.Builder
+	for _, r := range raw {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		} else if digits.Len() > 0 {
+			break
+		}
+	}
+	if digits.Len() == 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(digits.String())
+	return n
+}
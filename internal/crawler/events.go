@@ -0,0 +1,89 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/target"
+	"github.com/chromedp/chromedp"
+)
+
+// EventHandlerOptions controls how InstallEventHandlers reacts to
+// unexpected browser chrome during login and crawling.
+type EventHandlerOptions struct {
+	// LogDialogs logs the text of any JS dialog encountered, for debugging.
+	LogDialogs bool
+	// FatalOnDialog treats any unexpected dialog as a fatal error and
+	// invokes OnFatal instead of silently dismissing it.
+	FatalOnDialog bool
+	// OnFatal is called, if non-nil, when FatalOnDialog is set and a
+	// dialog is encountered.
+	OnFatal func(error)
+}
+
+// InstallEventHandlers attaches a chromedp.ListenTarget listener to ctx
+// that auto-dismisses JS alert/confirm/prompt dialogs (which would
+// otherwise hang Login/CheckForUpdate forever) and attaches to any tab the
+// forum opens on its own, closing it so it doesn't leak. Call this once per
+// browser context, right after chromedp.NewContext.
+func InstallEventHandlers(ctx context.Context, opts EventHandlerOptions) {
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		if e, ok := ev.(*page.EventJavascriptDialogOpening); ok {
+			handleDialog(ctx, e, opts)
+		}
+	})
+
+	// *target.EventTargetCreated carries no CDP session ID, so it is only
+	// ever delivered browser-wide, never to a ListenTarget listener (which
+	// is fed from a per-target, session-scoped queue). It must be caught
+	// with ListenBrowser or the new-tab branch never fires. Only targets with
+	// an opener (opened by a page) are handled; tabs the crawler creates
+	// itself through chromedp.NewContext have none and are left alone.
+	chromedp.ListenBrowser(ctx, func(ev interface{}) {
+		e, ok := ev.(*target.EventTargetCreated)
+		if ok && e.TargetInfo.Type == "page" && e.TargetInfo.OpenerID != "" {
+			go attachToNewTab(ctx, e.TargetInfo.TargetID)
+		}
+	})
+}
+
+// handleDialog always dismisses an unexpected dialog so it never blocks
+// Login/CheckForUpdate, additionally escalating it as a reported error when
+// opts.FatalOnDialog is set (e.g. a captcha iframe we can't solve
+// headlessly) — "fatal" means "also report", not "skip the dismiss".
+func handleDialog(ctx context.Context, e *page.EventJavascriptDialogOpening, opts EventHandlerOptions) {
+	if opts.LogDialogs {
+		log.Printf("💬 检测到弹窗 (%s): %s", e.Type, e.Message)
+	}
+
+	go func() {
+		if err := chromedp.Run(ctx, page.HandleJavaScriptDialog(true)); err != nil {
+			log.Printf("⚠️ 关闭弹窗失败: %v", err)
+		}
+	}()
+
+	if opts.FatalOnDialog && opts.OnFatal != nil {
+		opts.OnFatal(fmt.Errorf("检测到意外弹窗 (%s): %s", e.Type, e.Message))
+	}
+}
+
+// attachToNewTab folds a tab the forum opened on its own (e.g. an article
+// link with target="_blank") back into the pipeline by waiting for it to
+// settle and then closing it, so the crawl stays on the original tab
+// instead of accumulating orphaned ones.
+func attachToNewTab(parent context.Context, targetID target.ID) {
+	tabCtx, cancel := chromedp.NewContext(parent, chromedp.WithTargetID(targetID))
+	defer cancel()
+
+	if err := chromedp.Run(tabCtx, chromedp.WaitReady("body")); err != nil {
+		log.Printf("⚠️ 处理新标签页失败: %v", err)
+		return
+	}
+
+	log.Println("🗙 关闭意外打开的新标签页")
+	if err := chromedp.Cancel(tabCtx); err != nil {
+		log.Printf("⚠️ 关闭新标签页失败: %v", err)
+	}
+}
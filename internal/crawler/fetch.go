@@ -297,6 +297,10 @@ func Login(ctx context.Context, username, password string) error {
 			chromedp.WaitVisible(successSelector, chromedp.ByQuery),
 		)
 		if err != nil {
+			if selector, found := detectCaptcha(ctx); found {
+				log.Printf("🧩 检测到验证码 (%s)，需要人工介入", selector)
+				return &CaptchaError{Selector: selector}
+			}
 			return fmt.Errorf("登录验证失败: %w", err)
 		}
 	}
@@ -0,0 +1,87 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// UserAgentPool and AcceptLanguagePool are populated from config/param.toml
+// ([stealth] user_agents / accept_languages) so operators can rotate
+// fingerprints without a code change. Empty until main sets them.
+var (
+	UserAgentPool      []string
+	AcceptLanguagePool []string
+)
+
+// RandomUserAgent returns a random entry from UserAgentPool, or fallback if
+// the pool hasn't been configured.
+func RandomUserAgent(fallback string) string {
+	if len(UserAgentPool) == 0 {
+		return fallback
+	}
+	return UserAgentPool[rand.Intn(len(UserAgentPool))]
+}
+
+// RandomAcceptLanguage returns a random entry from AcceptLanguagePool, or
+// fallback if the pool hasn't been configured.
+func RandomAcceptLanguage(fallback string) string {
+	if len(AcceptLanguagePool) == 0 {
+		return fallback
+	}
+	return AcceptLanguagePool[rand.Intn(len(AcceptLanguagePool))]
+}
+
+// stealthScript is the standard puppeteer-extra-stealth patch set,
+// rewritten in plain JS: it hides navigator.webdriver, backfills a
+// plausible plugins/languages list, fakes window.chrome, spoofs the WebGL
+// vendor/renderer, and aligns the Notification.permission /
+// Permissions.query mismatch headless Chrome otherwise exposes.
+const stealthScript = `
+(() => {
+	Object.defineProperty(navigator, 'webdriver', { get: () => undefined });
+
+	Object.defineProperty(navigator, 'plugins', {
+		get: () => [1, 2, 3, 4, 5].map(() => ({ name: 'Chrome PDF Plugin' })),
+	});
+
+	Object.defineProperty(navigator, 'languages', {
+		get: () => ['zh-CN', 'zh', 'en-US', 'en'],
+	});
+
+	window.chrome = window.chrome || { runtime: {} };
+
+	const originalQuery = window.navigator.permissions.query;
+	window.navigator.permissions.query = (parameters) =>
+		parameters.name === 'notifications'
+			? Promise.resolve({ state: Notification.permission })
+			: originalQuery(parameters);
+
+	const getParameter = WebGLRenderingContext.prototype.getParameter;
+	WebGLRenderingContext.prototype.getParameter = function (parameter) {
+		if (parameter === 37445) return 'Intel Inc.';
+		if (parameter === 37446) return 'Intel Iris OpenGL Engine';
+		return getParameter.call(this, parameter);
+	};
+})();
+`
+
+// ApplyStealth injects stealthScript into every future document loaded in
+// ctx's tab via page.AddScriptToEvaluateOnNewDocument, so the overrides are
+// in place before the forum's own bot-detection scripts run. The script is
+// registered on that one target only: tabs later opened from ctx with
+// chromedp.NewContext do not get it. Call this once per browser context,
+// before any navigation.
+func ApplyStealth(ctx context.Context) error {
+	err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		_, err := page.AddScriptToEvaluateOnNewDocument(stealthScript).Do(ctx)
+		return err
+	}))
+	if err != nil {
+		return fmt.Errorf("注入反检测脚本失败: %w", err)
+	}
+	return nil
+}
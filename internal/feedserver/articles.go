@@ -0,0 +1,45 @@
+package feedserver
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"robomaster-monitor/internal/storage"
+)
+
+// handleArticles serves /articles?category=...&since=... as a JSON array
+// of storage.Article, for downstream tooling that wants structured queries
+// rather than a feed rendering. since must be RFC3339 if given.
+func handleArticles(w http.ResponseWriter, r *http.Request) {
+	if !withConditionalGET(w, r) {
+		return
+	}
+
+	category := r.URL.Query().Get("category")
+
+	var since time.Time
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			http.Error(w, "since 参数格式错误，需为 RFC3339", http.StatusBadRequest)
+			return
+		}
+		since = parsed
+	}
+
+	articles, err := storage.ListArticles(category, since)
+	if err != nil {
+		http.Error(w, "查询文章失败", http.StatusInternalServerError)
+		return
+	}
+	if articles == nil {
+		articles = []storage.Article{}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(articles); err != nil {
+		log.Printf("⚠️ 编码文章列表失败: %v", err)
+	}
+}
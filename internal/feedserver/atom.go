@@ -0,0 +1,82 @@
+package feedserver
+
+import (
+	"encoding/xml"
+	"log"
+	"net/http"
+	"time"
+
+	"robomaster-monitor/internal/storage"
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	Link    atomLink    `xml:"link"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	Link    atomLink    `xml:"link"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Author  *atomAuthor `xml:"author,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// handleAtom serves an Atom 1.0 rendering of the most recently discovered
+// articles.
+func handleAtom(w http.ResponseWriter, r *http.Request) {
+	if !withConditionalGET(w, r) {
+		return
+	}
+
+	articles, err := storage.ListArticles("", time.Time{})
+	if err != nil {
+		http.Error(w, "查询文章失败", http.StatusInternalServerError)
+		return
+	}
+	articles = limitArticles(articles, maxFeedItems)
+
+	updated := time.Now().UTC()
+	if len(articles) > 0 {
+		updated = articles[0].FetchedAt
+	}
+
+	feed := atomFeed{
+		Title:   "RoboMaster Monitor",
+		Link:    atomLink{Href: "https://bbs.robomaster.com/article"},
+		ID:      "https://bbs.robomaster.com/article",
+		Updated: updated.Format(time.RFC3339),
+	}
+	for _, a := range articles {
+		entry := atomEntry{
+			Title:   a.Title,
+			Link:    atomLink{Href: a.URL},
+			ID:      a.URL,
+			Updated: a.FetchedAt.Format(time.RFC3339),
+		}
+		if a.Author != "" {
+			entry.Author = &atomAuthor{Name: a.Author}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		log.Printf("⚠️ 编码 Atom 失败: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+// Package feedserver exposes the articles the monitor has discovered as an
+// RSS 2.0 / Atom 1.0 / JSON Feed 1.1 feed, plus a small JSON query API, so
+// users can subscribe from any feed reader instead of relying solely on the
+// configured notification channels.
+package feedserver
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"robomaster-monitor/internal/storage"
+)
+
+// maxFeedItems caps how many articles each feed format renders, so the
+// feed stays a reasonable size as the database grows.
+const maxFeedItems = 50
+
+// Start launches the feed server on addr (e.g. ":8090") in its own
+// goroutine. A failure to bind is logged, not returned, matching how the
+// rest of the monitor treats background services as best-effort.
+func Start(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/feed.rss", handleRSS)
+	mux.HandleFunc("/feed.atom", handleAtom)
+	mux.HandleFunc("/feed.json", handleJSONFeed)
+	mux.HandleFunc("/articles", handleArticles)
+
+	go func() {
+		log.Printf("📡 Feed 服务器启动于 %s", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("❌ Feed 服务器退出: %v", err)
+		}
+	}()
+}
+
+// withConditionalGET sets ETag/Last-Modified from the most recent article
+// fetch time and replies 304 Not Modified if the client's cache is already
+// current. It returns false once it has written the response itself, in
+// which case the handler should return immediately.
+func withConditionalGET(w http.ResponseWriter, r *http.Request) bool {
+	latest, err := storage.LatestFetchedAt()
+	if err != nil {
+		log.Printf("⚠️ 查询最新抓取时间失败: %v", err)
+		return true
+	}
+	if latest.IsZero() {
+		return true
+	}
+
+	etag := fmt.Sprintf(`"%d"`, latest.Unix())
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", latest.Format(http.TimeFormat))
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return false
+	}
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		if t, err := http.ParseTime(ims); err == nil && !latest.After(t) {
+			w.WriteHeader(http.StatusNotModified)
+			return false
+		}
+	}
+	return true
+}
+
+// limitArticles truncates articles to at most n entries, newest first.
+func limitArticles(articles []storage.Article, n int) []storage.Article {
+	if len(articles) > n {
+		return articles[:n]
+	}
+	return articles
+}
@@ -0,0 +1,66 @@
+package feedserver
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"robomaster-monitor/internal/storage"
+)
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string          `json:"id"`
+	URL           string          `json:"url"`
+	Title         string          `json:"title"`
+	DatePublished string          `json:"date_published"`
+	Author        *jsonFeedAuthor `json:"author,omitempty"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// handleJSONFeed serves a JSON Feed 1.1 rendering of the most recently
+// discovered articles.
+func handleJSONFeed(w http.ResponseWriter, r *http.Request) {
+	if !withConditionalGET(w, r) {
+		return
+	}
+
+	articles, err := storage.ListArticles("", time.Time{})
+	if err != nil {
+		http.Error(w, "查询文章失败", http.StatusInternalServerError)
+		return
+	}
+
+	feed := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       "RoboMaster Monitor",
+		HomePageURL: "https://bbs.robomaster.com/article",
+	}
+	for _, a := range limitArticles(articles, maxFeedItems) {
+		item := jsonFeedItem{
+			ID:            a.URL,
+			URL:           a.URL,
+			Title:         a.Title,
+			DatePublished: a.FetchedAt.Format(time.RFC3339),
+		}
+		if a.Author != "" {
+			item.Author = &jsonFeedAuthor{Name: a.Author}
+		}
+		feed.Items = append(feed.Items, item)
+	}
+
+	w.Header().Set("Content-Type", "application/feed+json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(feed); err != nil {
+		log.Printf("⚠️ 编码 JSON Feed 失败: %v", err)
+	}
+}
@@ -0,0 +1,71 @@
+package feedserver
+
+import (
+	"encoding/xml"
+	"log"
+	"net/http"
+	"time"
+
+	"robomaster-monitor/internal/storage"
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title   string `xml:"title"`
+	Link    string `xml:"link"`
+	GUID    string `xml:"guid"`
+	PubDate string `xml:"pubDate"`
+	Author  string `xml:"author,omitempty"`
+}
+
+// handleRSS serves an RSS 2.0 rendering of the most recently discovered
+// articles.
+func handleRSS(w http.ResponseWriter, r *http.Request) {
+	if !withConditionalGET(w, r) {
+		return
+	}
+
+	articles, err := storage.ListArticles("", time.Time{})
+	if err != nil {
+		http.Error(w, "查询文章失败", http.StatusInternalServerError)
+		return
+	}
+
+	feed := rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       "RoboMaster Monitor",
+			Link:        "https://bbs.robomaster.com/article",
+			Description: "DJI RoboMaster 论坛新帖监控",
+		},
+	}
+	for _, a := range limitArticles(articles, maxFeedItems) {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:   a.Title,
+			Link:    a.URL,
+			GUID:    a.URL,
+			PubDate: a.FetchedAt.Format(time.RFC1123Z),
+			Author:  a.Author,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		log.Printf("⚠️ 编码 RSS 失败: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// dingTalkNotifier delivers new-article notifications to a DingTalk custom
+// robot webhook using its markdown message type.
+type dingTalkNotifier struct {
+	webhookURL string
+	limiter    *rate.Limiter
+}
+
+func newDingTalkNotifier(c ChannelConfig) *dingTalkNotifier {
+	return &dingTalkNotifier{webhookURL: c.WebhookURL, limiter: newLimiter(c.RateLimitPerMin)}
+}
+
+func (n *dingTalkNotifier) Channel() string { return "dingtalk" }
+
+func (n *dingTalkNotifier) Notify(title, url, preview, thumbnailURL string) error {
+	_ = n.limiter.Wait(context.Background())
+	return withRetry(func() error { return n.post(title, url, preview, thumbnailURL) })
+}
+
+func (n *dingTalkNotifier) post(title, url, preview, thumbnailURL string) error {
+	if n.webhookURL == "" {
+		return nil
+	}
+
+	payload := struct {
+		MsgType  string `json:"msgtype"`
+		Markdown struct {
+			Title string `json:"title"`
+			Text  string `json:"text"`
+		} `json:"markdown"`
+	}{MsgType: "markdown"}
+	payload.Markdown.Title = "发现新文章"
+	text := fmt.Sprintf("#### 发现新文章\n[%s](%s)", title, url)
+	if thumbnailURL != "" {
+		text += fmt.Sprintf("\n![](%s)", thumbnailURL)
+	}
+	if preview != "" {
+		text += fmt.Sprintf("\n%s", preview)
+	}
+	payload.Markdown.Text = text
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("序列化通知内容失败: %w", err)
+	}
+
+	resp, err := httpClient.Post(n.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("发送钉钉通知失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("钉钉通知返回非 200 状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// feishuRateLimitCode is the code Feishu's custom bot webhook returns in
+// its JSON body (alongside an HTTP 200) when a webhook is being sent to
+// too quickly.
+const feishuRateLimitCode = 19999
+
+// feishuResponse is the envelope every Feishu custom bot webhook reply is
+// wrapped in.
+type feishuResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// feishuNotifier delivers new-article notifications to a Feishu custom bot
+// webhook, retrying through withRetry on a 19999 rate-limit response.
+type feishuNotifier struct {
+	webhookURL string
+	limiter    *rate.Limiter
+}
+
+func newFeishuNotifier(c ChannelConfig) *feishuNotifier {
+	return &feishuNotifier{webhookURL: c.WebhookURL, limiter: newLimiter(c.RateLimitPerMin)}
+}
+
+func (n *feishuNotifier) Channel() string { return "feishu" }
+
+func (n *feishuNotifier) Notify(title, url, preview, thumbnailURL string) error {
+	_ = n.limiter.Wait(context.Background())
+	text := fmt.Sprintf("📢 发现新文章\n%s\n%s", title, url)
+	if preview != "" {
+		text += fmt.Sprintf("\n%s", preview)
+	}
+	return withRetry(func() error {
+		return feishuPost(n.webhookURL, text)
+	})
+}
+
+// SendError notifies webhookURL that the monitor hit an error it couldn't
+// recover from on its own.
+func SendError(webhookURL, message string) error {
+	return feishuPost(webhookURL, fmt.Sprintf("🔥 RoboMaster Monitor 出错\n%s", message))
+}
+
+// SendCaptchaAlert notifies webhookURL that a login attempt is blocked on a
+// captcha and needs a human to solve it, including a resumable token so an
+// operator can tell which run is waiting.
+func SendCaptchaAlert(webhookURL, token string) error {
+	return feishuPost(webhookURL, fmt.Sprintf("🧩 登录遇到验证码，需要人工处理\n恢复令牌: %s", token))
+}
+
+// feishuPost sends text as a Feishu custom bot text message, returning a
+// *rateLimitedErr if Feishu's own 19999 code comes back.
+func feishuPost(webhookURL, text string) error {
+	if webhookURL == "" {
+		return nil
+	}
+
+	payload := struct {
+		MsgType string `json:"msg_type"`
+		Content struct {
+			Text string `json:"text"`
+		} `json:"content"`
+	}{MsgType: "text"}
+	payload.Content.Text = text
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("序列化通知内容失败: %w", err)
+	}
+
+	resp, err := httpClient.Post(webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("发送飞书通知失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("飞书通知返回非 200 状态码: %d", resp.StatusCode)
+	}
+
+	var parsed feishuResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err == nil && parsed.Code == feishuRateLimitCode {
+		return &rateLimitedErr{RetryAfter: 2 * time.Second}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+// Package notifier delivers monitor events to external channels: the
+// always-on Feishu webhook used for operational alerts (errors, captcha
+// prompts), and the pluggable, multi-channel Notifier fan-out used for
+// newly discovered articles.
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// Notifier delivers new-article notifications to a single channel. A
+// channel that already succeeded for a given article should never be
+// retried just because another channel failed — see
+// storage.MarkNotifiedOn.
+type Notifier interface {
+	// Channel identifies this notifier for logging and for
+	// storage.MarkNotifiedOn.
+	Channel() string
+	// Notify delivers a new-article notification for title/url. preview and
+	// thumbnailURL are a short body snippet and the first image pulled from
+	// the article's fetched detail (see storage.GetArticleDetail), and are
+	// empty if no detail was fetched for this article — implementations
+	// should treat them as optional and still notify without them.
+	Notify(title, url, preview, thumbnailURL string) error
+}
+
+// ChannelConfig configures one entry of the `[[channel]]` TOML array.
+// Which fields apply depends on Type ("feishu", "dingtalk", "slack",
+// "webhook", or "smtp"), so operators can add a new sink by appending a
+// table rather than changing code.
+type ChannelConfig struct {
+	Type            string   `toml:"type"`
+	WebhookURL      string   `toml:"webhook_url"`
+	SMTPHost        string   `toml:"smtp_host"`
+	SMTPPort        int      `toml:"smtp_port"`
+	SMTPUsername    string   `toml:"smtp_username"`
+	SMTPPassword    string   `toml:"smtp_password"`
+	From            string   `toml:"from"`
+	To              []string `toml:"to"`
+	RateLimitPerMin int      `toml:"rate_limit_per_minute"`
+}
+
+// BuildNotifiers constructs one Notifier per configured channel. An entry
+// with an unrecognized Type is logged and skipped rather than failing
+// startup, so a typo in one channel doesn't take down the others.
+func BuildNotifiers(configs []ChannelConfig) []Notifier {
+	notifiers := make([]Notifier, 0, len(configs))
+	for _, c := range configs {
+		switch c.Type {
+		case "feishu":
+			notifiers = append(notifiers, newFeishuNotifier(c))
+		case "dingtalk":
+			notifiers = append(notifiers, newDingTalkNotifier(c))
+		case "slack":
+			notifiers = append(notifiers, newSlackNotifier(c))
+		case "webhook":
+			notifiers = append(notifiers, newWebhookNotifier(c))
+		case "smtp":
+			notifiers = append(notifiers, newSMTPNotifier(c))
+		default:
+			log.Printf("⚠️ 未知的通知渠道类型: %q，已跳过", c.Type)
+		}
+	}
+	return notifiers
+}
+
+// newLimiter builds a per-channel rate limiter from a TOML
+// rate_limit_per_minute setting, defaulting to 30/min (one every 2s) when
+// unset so a misconfigured channel can't be left unbounded.
+func newLimiter(perMinute int) *rate.Limiter {
+	if perMinute <= 0 {
+		perMinute = 30
+	}
+	return rate.NewLimiter(rate.Limit(float64(perMinute)/60), 1)
+}
+
+// rateLimitedErr signals that a channel itself reported it is being
+// rate-limited (e.g. Feishu's 19999 response code) and how long withRetry
+// should back off before trying again.
+type rateLimitedErr struct {
+	RetryAfter time.Duration
+}
+
+func (e *rateLimitedErr) Error() string {
+	return fmt.Sprintf("请求被限流，建议 %v 后重试", e.RetryAfter)
+}
+
+// withRetry calls fn up to three times with exponential backoff, honoring
+// a rateLimitedErr's suggested delay instead of the normal one.
+func withRetry(fn func() error) error {
+	const maxAttempts = 3
+	backoff := 1 * time.Second
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		if attempt == maxAttempts {
+			break
+		}
+
+		var rl *rateLimitedErr
+		if errors.As(err, &rl) && rl.RetryAfter > 0 {
+			time.Sleep(rl.RetryAfter)
+		} else {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// slackNotifier delivers new-article notifications to a Slack incoming
+// webhook.
+type slackNotifier struct {
+	webhookURL string
+	limiter    *rate.Limiter
+}
+
+func newSlackNotifier(c ChannelConfig) *slackNotifier {
+	return &slackNotifier{webhookURL: c.WebhookURL, limiter: newLimiter(c.RateLimitPerMin)}
+}
+
+func (n *slackNotifier) Channel() string { return "slack" }
+
+func (n *slackNotifier) Notify(title, url, preview, thumbnailURL string) error {
+	_ = n.limiter.Wait(context.Background())
+	return withRetry(func() error { return n.post(title, url, preview, thumbnailURL) })
+}
+
+func (n *slackNotifier) post(title, url, preview, thumbnailURL string) error {
+	if n.webhookURL == "" {
+		return nil
+	}
+
+	text := fmt.Sprintf("📢 发现新文章: <%s|%s>", url, title)
+	if preview != "" {
+		text += fmt.Sprintf("\n%s", preview)
+	}
+	if thumbnailURL != "" {
+		text += fmt.Sprintf("\n%s", thumbnailURL)
+	}
+
+	payload := struct {
+		Text string `json:"text"`
+	}{Text: text}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("序列化通知内容失败: %w", err)
+	}
+
+	resp, err := httpClient.Post(n.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("发送 Slack 通知失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Slack 通知返回非 200 状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"golang.org/x/time/rate"
+)
+
+// smtpNotifier delivers new-article notifications as a plain-text email.
+type smtpNotifier struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	to       []string
+	limiter  *rate.Limiter
+}
+
+func newSMTPNotifier(c ChannelConfig) *smtpNotifier {
+	return &smtpNotifier{
+		host:     c.SMTPHost,
+		port:     c.SMTPPort,
+		username: c.SMTPUsername,
+		password: c.SMTPPassword,
+		from:     c.From,
+		to:       c.To,
+		limiter:  newLimiter(c.RateLimitPerMin),
+	}
+}
+
+func (n *smtpNotifier) Channel() string { return "smtp" }
+
+func (n *smtpNotifier) Notify(title, url, preview, thumbnailURL string) error {
+	_ = n.limiter.Wait(context.Background())
+	return withRetry(func() error { return n.send(title, url, preview, thumbnailURL) })
+}
+
+func (n *smtpNotifier) send(title, url, preview, thumbnailURL string) error {
+	if n.host == "" || len(n.to) == 0 {
+		return nil
+	}
+
+	// title comes from scraped forum text (any forum member can set a
+	// thread title), so it's effectively attacker-controlled. Strip CR/LF
+	// before it's interpolated into a raw header block, or a crafted title
+	// could inject extra SMTP headers (e.g. a Bcc:). url gets the same
+	// treatment for defense in depth.
+	title = stripCRLF(title)
+	url = stripCRLF(url)
+
+	body := fmt.Sprintf("%s\r\n%s\r\n", title, url)
+	if preview != "" {
+		body += fmt.Sprintf("\r\n%s\r\n", preview)
+	}
+	if thumbnailURL != "" {
+		body += fmt.Sprintf("\r\n%s\r\n", thumbnailURL)
+	}
+
+	addr := fmt.Sprintf("%s:%d", n.host, n.port)
+	auth := smtp.PlainAuth("", n.username, n.password, n.host)
+
+	msg := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: RoboMaster Monitor: %s\r\n\r\n%s",
+		n.from, strings.Join(n.to, ", "), title, body,
+	)
+
+	if err := smtp.SendMail(addr, auth, n.from, n.to, []byte(msg)); err != nil {
+		return fmt.Errorf("发送邮件通知失败: %w", err)
+	}
+	return nil
+}
+
+// stripCRLF removes carriage-return and newline characters from s so it
+// can't break out of a raw SMTP header line when interpolated into one.
+func stripCRLF(s string) string {
+	s = strings.ReplaceAll(s, "\r", "")
+	s = strings.ReplaceAll(s, "\n", "")
+	return s
+}
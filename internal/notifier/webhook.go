@@ -0,0 +1,57 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+// webhookNotifier POSTs a plain JSON payload to an arbitrary operator-owned
+// URL, for sinks that don't fit one of the named channels.
+type webhookNotifier struct {
+	url     string
+	limiter *rate.Limiter
+}
+
+func newWebhookNotifier(c ChannelConfig) *webhookNotifier {
+	return &webhookNotifier{url: c.WebhookURL, limiter: newLimiter(c.RateLimitPerMin)}
+}
+
+func (n *webhookNotifier) Channel() string { return "webhook" }
+
+func (n *webhookNotifier) Notify(title, url, preview, thumbnailURL string) error {
+	_ = n.limiter.Wait(context.Background())
+	return withRetry(func() error { return n.post(title, url, preview, thumbnailURL) })
+}
+
+func (n *webhookNotifier) post(title, articleURL, preview, thumbnailURL string) error {
+	if n.url == "" {
+		return nil
+	}
+
+	payload := struct {
+		Title        string `json:"title"`
+		URL          string `json:"url"`
+		Preview      string `json:"preview,omitempty"`
+		ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	}{Title: title, URL: articleURL, Preview: preview, ThumbnailURL: thumbnailURL}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("序列化通知内容失败: %w", err)
+	}
+
+	resp, err := httpClient.Post(n.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("发送 webhook 通知失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook 通知返回非成功状态码: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// ArticleDetail holds the body content extracted from an article's own
+// page, fetched lazily by crawler.FetchArticleDetails after the article
+// itself is first discovered by CheckForUpdate.
+type ArticleDetail struct {
+	ArticleID    int64
+	Body         string
+	Images       []string
+	Tags         []string
+	ViewCount    int
+	ReplyCount   int
+	LastEditedAt string
+}
+
+func init() {
+	const schema = `
+	CREATE TABLE IF NOT EXISTS article_details (
+		article_id     INTEGER PRIMARY KEY,
+		body           TEXT,
+		images         TEXT,
+		tags           TEXT,
+		view_count     INTEGER NOT NULL DEFAULT 0,
+		reply_count    INTEGER NOT NULL DEFAULT 0,
+		last_edited_at TEXT
+	);`
+	registerSchema(schema)
+}
+
+// SaveArticleDetail persists the fully-fetched detail content for an
+// article, replacing any previously stored detail for the same article.
+func SaveArticleDetail(detail *ArticleDetail) error {
+	images, err := json.Marshal(detail.Images)
+	if err != nil {
+		return fmt.Errorf("序列化图片列表失败: %w", err)
+	}
+	tags, err := json.Marshal(detail.Tags)
+	if err != nil {
+		return fmt.Errorf("序列化标签列表失败: %w", err)
+	}
+
+	_, err = db.Exec(
+		`INSERT OR REPLACE INTO article_details (article_id, body, images, tags, view_count, reply_count, last_edited_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		detail.ArticleID, detail.Body, string(images), string(tags), detail.ViewCount, detail.ReplyCount, detail.LastEditedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("保存文章详情失败: %w", err)
+	}
+	return nil
+}
+
+// GetArticleDetail returns the previously fetched detail for articleID, and
+// false if FetchArticleDetails hasn't saved one yet (e.g. it failed or
+// hasn't run for this article).
+func GetArticleDetail(articleID int64) (*ArticleDetail, bool, error) {
+	var detail ArticleDetail
+	var images, tags string
+
+	err := db.QueryRow(
+		`SELECT article_id, body, images, tags, view_count, reply_count, last_edited_at FROM article_details WHERE article_id = ?`,
+		articleID,
+	).Scan(&detail.ArticleID, &detail.Body, &images, &tags, &detail.ViewCount, &detail.ReplyCount, &detail.LastEditedAt)
+	if err == sql.ErrNoRows {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("查询文章详情失败: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(images), &detail.Images); err != nil {
+		return nil, false, fmt.Errorf("解析图片列表失败: %w", err)
+	}
+	if err := json.Unmarshal([]byte(tags), &detail.Tags); err != nil {
+		return nil, false, fmt.Errorf("解析标签列表失败: %w", err)
+	}
+
+	return &detail, true, nil
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// ListArticles returns articles fetched at or after since, optionally
+// restricted to category, newest first. Either filter may be left at its
+// zero value to mean "no restriction". Used by internal/feedserver.
+func ListArticles(category string, since time.Time) ([]Article, error) {
+	query := `SELECT id, title, url, author, category, posted_at, notified, fetched_at FROM articles WHERE 1 = 1`
+	var args []interface{}
+
+	if category != "" {
+		query += ` AND category = ?`
+		args = append(args, category)
+	}
+	if !since.IsZero() {
+		query += ` AND fetched_at >= ?`
+		args = append(args, since.UTC().Format(time.RFC3339))
+	}
+	query += ` ORDER BY fetched_at DESC`
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("查询文章列表失败: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		var a Article
+		var fetchedAt string
+		if err := rows.Scan(&a.ID, &a.Title, &a.URL, &a.Author, &a.Category, &a.PostedAt, &a.Notified, &fetchedAt); err != nil {
+			return nil, fmt.Errorf("读取文章记录失败: %w", err)
+		}
+		if fetchedAt != "" {
+			if a.FetchedAt, err = time.Parse(time.RFC3339, fetchedAt); err != nil {
+				return nil, fmt.Errorf("解析抓取时间失败: %w", err)
+			}
+		}
+		articles = append(articles, a)
+	}
+	return articles, rows.Err()
+}
+
+// LatestFetchedAt returns the most recent FetchedAt across all articles,
+// the zero time if there are none yet. feedserver uses it to build
+// ETag/Last-Modified headers for conditional GETs.
+func LatestFetchedAt() (time.Time, error) {
+	var raw string
+	if err := db.QueryRow(`SELECT COALESCE(MAX(fetched_at), '') FROM articles`).Scan(&raw); err != nil {
+		return time.Time{}, fmt.Errorf("查询最新抓取时间失败: %w", err)
+	}
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("解析抓取时间失败: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,158 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+var (
+	db           *sql.DB
+	extraSchemas []string
+)
+
+// registerSchema queues an additional CREATE TABLE statement to run as
+// part of InitDB. Files that add their own tables (e.g. article_detail.go)
+// call this from an init func so the schema lives next to the code that
+// uses it.
+func registerSchema(schema string) {
+	extraSchemas = append(extraSchemas, schema)
+}
+
+// Article holds a single forum post tracked by the monitor.
+type Article struct {
+	ID       int64
+	Title    string
+	URL      string
+	Author   string
+	Category string
+	PostedAt string
+	Notified bool
+	// FetchedAt is when the monitor itself discovered the article, not
+	// when the forum says it was posted (PostedAt is forum-displayed text
+	// like "3小时前" and isn't reliably parseable). feedserver uses this
+	// for since filtering and conditional-GET headers.
+	FetchedAt time.Time
+}
+
+// InitDB opens (creating if necessary) the sqlite database at path and
+// ensures the schema required by the monitor exists.
+func InitDB(path string) error {
+	var err error
+	db, err = sql.Open("sqlite3", path)
+	if err != nil {
+		return fmt.Errorf("打开数据库失败: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return fmt.Errorf("连接数据库失败: %w", err)
+	}
+
+	const schema = `
+	CREATE TABLE IF NOT EXISTS articles (
+		id                INTEGER PRIMARY KEY AUTOINCREMENT,
+		title             TEXT NOT NULL,
+		url               TEXT NOT NULL UNIQUE,
+		author            TEXT,
+		category          TEXT,
+		posted_at         TEXT,
+		notified          INTEGER NOT NULL DEFAULT 0,
+		notified_channels TEXT NOT NULL DEFAULT '',
+		fetched_at        TEXT NOT NULL DEFAULT ''
+	);`
+
+	if _, err := db.Exec(schema); err != nil {
+		return fmt.Errorf("初始化表结构失败: %w", err)
+	}
+
+	for _, extra := range extraSchemas {
+		if _, err := db.Exec(extra); err != nil {
+			return fmt.Errorf("初始化表结构失败: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Close releases the underlying database handle.
+func Close() error {
+	if db == nil {
+		return nil
+	}
+	return db.Close()
+}
+
+// ArticleExists reports whether an article with the given URL has already
+// been recorded.
+func ArticleExists(url string) (bool, error) {
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(1) FROM articles WHERE url = ?`, url).Scan(&count); err != nil {
+		return false, fmt.Errorf("查询文章失败: %w", err)
+	}
+	return count > 0, nil
+}
+
+// SaveArticle inserts a new article, stamping FetchedAt with the current
+// time, and returns its assigned ID.
+func SaveArticle(article *Article) (int64, error) {
+	article.FetchedAt = time.Now().UTC()
+
+	res, err := db.Exec(
+		`INSERT INTO articles (title, url, author, category, posted_at, notified, fetched_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		article.Title, article.URL, article.Author, article.Category, article.PostedAt, article.Notified,
+		article.FetchedAt.Format(time.RFC3339),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("保存文章失败: %w", err)
+	}
+	return res.LastInsertId()
+}
+
+// NotifiedChannels returns the channels an article has already been
+// successfully delivered to.
+func NotifiedChannels(id int64) ([]string, error) {
+	var raw string
+	if err := db.QueryRow(`SELECT notified_channels FROM articles WHERE id = ?`, id).Scan(&raw); err != nil {
+		return nil, fmt.Errorf("查询通知渠道失败: %w", err)
+	}
+	return splitChannels(raw), nil
+}
+
+// MarkNotifiedOn records that article id was successfully delivered to
+// channel, keeping any channels already recorded. This lets the fan-out in
+// runPipeline skip re-sending to a channel that already succeeded when a
+// different channel failed and the article is retried.
+//
+// The append is done as a single UPDATE, not a SELECT-then-UPDATE, because
+// notifyArticle calls this concurrently from one goroutine per channel for
+// the same article id: a read-modify-write here would let two goroutines
+// read the same starting value and have the second clobber the first's
+// channel instead of appending to it.
+func MarkNotifiedOn(id int64, channel string) error {
+	if _, err := db.Exec(
+		`UPDATE articles
+		 SET notified = 1,
+		     notified_channels = CASE
+		         WHEN notified_channels = '' THEN ?
+		         ELSE notified_channels || ',' || ?
+		     END
+		 WHERE id = ?
+		   AND (',' || notified_channels || ',') NOT LIKE ('%,' || ? || ',%')`,
+		channel, channel, id, channel,
+	); err != nil {
+		return fmt.Errorf("更新通知状态失败: %w", err)
+	}
+	return nil
+}
+
+// splitChannels parses the comma-separated notified_channels column,
+// returning nil (not a slice containing "") for an empty value.
+func splitChannels(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+	return strings.Split(raw, ",")
+}
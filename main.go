@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"math/rand"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -14,6 +16,7 @@ import (
 	"github.com/fsnotify/fsnotify"
 
 	"robomaster-monitor/internal/crawler"
+	"robomaster-monitor/internal/feedserver"
 	"robomaster-monitor/internal/notifier"
 	"robomaster-monitor/internal/storage"
 )
@@ -41,12 +44,40 @@ type Config struct {
 	} `toml:"feishu"`
 
 	Browser struct {
-		Headless           bool   `toml:"headless"`
-		NoSandbox          bool   `toml:"no_sandbox"`
-		DisableGPU         bool   `toml:"disable_gpu"`
-		DisableDevShmUsage bool   `toml:"disable_dev_shm_usage"`
-		UserAgent          string `toml:"user_agent"`
+		Headless             bool   `toml:"headless"`
+		NoSandbox            bool   `toml:"no_sandbox"`
+		DisableGPU           bool   `toml:"disable_gpu"`
+		DisableDevShmUsage   bool   `toml:"disable_dev_shm_usage"`
+		UserAgent            string `toml:"user_agent"`
+		InteractiveOnCaptcha bool   `toml:"interactive_on_captcha"`
 	} `toml:"browser"`
+
+	// Channel is the `[[channel]]` array operators extend to add a new
+	// notification sink without touching code; see notifier.ChannelConfig.
+	Channel []notifier.ChannelConfig `toml:"channel"`
+
+	Stealth struct {
+		UserAgents      []string `toml:"user_agents"`
+		AcceptLanguages []string `toml:"accept_languages"`
+	} `toml:"stealth"`
+
+	FeedServer struct {
+		Enabled bool   `toml:"enabled"`
+		Addr    string `toml:"addr"`
+	} `toml:"feed_server"`
+
+	Crawler struct {
+		// BlockResources skips images/fonts/media and known ad/analytics
+		// domains via fetch interception.
+		BlockResources bool `toml:"block_resources"`
+		// BlockImages is the heavier-handed --blink-settings=imagesEnabled=false
+		// toggle, for when even interception overhead isn't wanted.
+		BlockImages bool `toml:"block_images"`
+		// BlockedDomains overrides crawler.defaultBlockedDomains with an
+		// operator-tunable ad/tracking blocklist, used when BlockResources
+		// is on.
+		BlockedDomains []string `toml:"blocked_domains"`
+	} `toml:"crawler"`
 }
 
 var config Config
@@ -56,6 +87,11 @@ func loadConfig(path string) {
 	if _, err := toml.DecodeFile(path, &config); err != nil {
 		log.Fatalf("❌  读取配置文件失败: %v", err)
 	}
+
+	crawler.UserAgentPool = config.Stealth.UserAgents
+	crawler.AcceptLanguagePool = config.Stealth.AcceptLanguages
+	crawler.BlockedDomains = config.Crawler.BlockedDomains
+
 	log.Println("✅  配置文件加载成功")
 }
 
@@ -93,6 +129,58 @@ func watchConfig(path string) {
 	}
 }
 
+// newBrowserContext creates a fresh chromedp allocator and context from the
+// configured browser flags, overriding headless explicitly so the same
+// options can build both the normal headless run and the non-headless
+// window used for human-in-the-loop captcha handling. allowResourceBlocking
+// gates config.Crawler.BlockResources/BlockImages; the interactive captcha
+// window always passes false, since a human needs to actually see the
+// slider/image captcha those settings would otherwise strip out.
+func newBrowserContext(headless bool, timeout time.Duration, allowResourceBlocking bool) (context.Context, context.CancelFunc) {
+	userAgent := crawler.RandomUserAgent(config.Browser.UserAgent)
+	acceptLanguage := crawler.RandomAcceptLanguage("zh-CN,zh;q=0.9,en-US;q=0.8")
+
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.Flag("headless", headless),
+		chromedp.Flag("no-sandbox", config.Browser.NoSandbox),
+		chromedp.Flag("disable-gpu", config.Browser.DisableGPU),
+		chromedp.Flag("disable-dev-shm-usage", config.Browser.DisableDevShmUsage),
+		chromedp.Flag("disable-blink-features", "AutomationControlled"),
+		chromedp.Flag("disable-web-security", false),
+		chromedp.Flag("disable-features", "IsolateOrigins,site-per-process,AutomationControlled"),
+		chromedp.Flag("exclude-switches", "enable-automation"),
+		chromedp.Flag("lang", acceptLanguage),
+		chromedp.WindowSize(1920, 1080),
+		chromedp.UserAgent(userAgent),
+	)
+
+	if allowResourceBlocking && config.Crawler.BlockImages {
+		opts = append(opts, chromedp.Flag("blink-settings", "imagesEnabled=false"))
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	ctx, ctxCancel := chromedp.NewContext(allocCtx, chromedp.WithLogf(log.Printf))
+	ctx, timeoutCancel := context.WithTimeout(ctx, timeout)
+
+	if err := crawler.ApplyStealth(ctx); err != nil {
+		log.Printf("⚠️ 应用反检测脚本失败: %v", err)
+	}
+
+	if allowResourceBlocking && config.Crawler.BlockResources {
+		if err := crawler.WithResourceBlocking(ctx); err != nil {
+			log.Printf("⚠️ 启用资源拦截失败: %v", err)
+		}
+	}
+
+	cancel := func() {
+		timeoutCancel()
+		ctxCancel()
+		allocCancel()
+	}
+
+	return ctx, cancel
+}
+
 // runPipeline
 func runPipeline() {
 	// 异常捕获
@@ -106,7 +194,6 @@ func runPipeline() {
 
 	username := config.DJI.Username
 	password := config.DJI.Password
-	webhookURL := config.Feishu.WebhookURL
 
 	if username == "" || password == "" {
 		log.Println("⚠️ 缺少用户名或密码，跳过执行")
@@ -114,31 +201,61 @@ func runPipeline() {
 	}
 
 	// create context
-	opts := append(chromedp.DefaultExecAllocatorOptions[:],
-		chromedp.Flag("headless", config.Browser.Headless),
-		chromedp.Flag("no-sandbox", config.Browser.NoSandbox),
-		chromedp.Flag("disable-gpu", config.Browser.DisableGPU),
-		chromedp.Flag("disable-dev-shm-usage", config.Browser.DisableDevShmUsage),
-		chromedp.Flag("disable-blink-features", "AutomationControlled"),
-		chromedp.Flag("disable-web-security", false),
-		chromedp.Flag("disable-features", "IsolateOrigins,site-per-process"),
-		chromedp.WindowSize(1920, 1080),
-		chromedp.UserAgent(config.Browser.UserAgent),
-	)
-
-	allocCtx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	ctx, cancel := newBrowserContext(config.Browser.Headless, 5*time.Minute, true)
 	defer cancel()
 
-	ctx, cancel := chromedp.NewContext(allocCtx, chromedp.WithLogf(log.Printf))
-	defer cancel()
-
-	// set timeout (增加超时时间以适应更慢的操作)
-	ctx, cancel = context.WithTimeout(ctx, 5*time.Minute)
-	defer cancel()
+	// 安装弹窗/新标签页拦截，避免验证码或意外弹窗把流程卡死
+	crawler.InstallEventHandlers(ctx, crawler.EventHandlerOptions{
+		LogDialogs:    true,
+		FatalOnDialog: true,
+		OnFatal:       notifyError,
+	})
 
 	// login
 	log.Println("🔐 开始登录...")
 	err := crawler.Login(ctx, username, password)
+
+	var captchaErr *crawler.CaptchaError
+	if errors.As(err, &captchaErr) {
+		if !config.Browser.InteractiveOnCaptcha {
+			log.Printf("❌ 登录失败: %v", err)
+			notifyError(fmt.Errorf("登录失败: %w", err))
+			return
+		}
+
+		log.Println("🧩 检测到验证码，切换为非无头窗口等待人工处理...")
+		cancel()
+
+		// 交互式验证码窗口必须让人类看清验证码本身，资源/图片拦截全部强制关闭，
+		// 不受 config.Crawler.BlockResources/BlockImages 影响
+		ctx, cancel = newBrowserContext(false, 10*time.Minute, false)
+		defer cancel()
+		crawler.InstallEventHandlers(ctx, crawler.EventHandlerOptions{
+			LogDialogs:    true,
+			FatalOnDialog: true,
+			OnFatal:       notifyError,
+		})
+
+		// 新窗口是空白页，先在可见窗口中重新登录，让验证码真正出现在人眼前
+		err = crawler.Login(ctx, username, password)
+		if errors.As(err, &captchaErr) {
+			token := fmt.Sprintf("%08x", rand.Uint32())
+			if config.Feishu.ErrorWebhookURL != "" {
+				if sendErr := notifier.SendCaptchaAlert(config.Feishu.ErrorWebhookURL, token); sendErr != nil {
+					log.Printf("⚠️ 发送验证码提醒失败: %v", sendErr)
+				}
+			}
+
+			if waitErr := crawler.WaitForHumanUnlock(ctx, captchaErr.Selector, 10*time.Minute); waitErr != nil {
+				log.Printf("❌ 等待人工处理验证码失败: %v", waitErr)
+				notifyError(fmt.Errorf("验证码处理超时: %w", waitErr))
+				return
+			}
+
+			err = crawler.Login(ctx, username, password)
+		}
+	}
+
 	if err != nil {
 		log.Printf("❌ 登录失败: %v", err)
 		notifyError(fmt.Errorf("登录失败: %w", err))
@@ -157,29 +274,100 @@ func runPipeline() {
 		return
 	}
 
+	// fetch full article content before notifying so channels can include a preview
+	if len(newArticles) > 0 {
+		log.Printf("📥 并发抓取 %d 篇文章详情...", len(newArticles))
+		crawler.FetchArticleDetails(ctx, newArticles)
+	}
+
 	// send notifications
 	if len(newArticles) > 0 {
 		log.Printf("🔔 准备通知 %d 篇新文章...", len(newArticles))
 
+		notifiers := notifier.BuildNotifiers(config.Channel)
 		for _, article := range newArticles {
-			if webhookURL != "" {
-
-				log.Printf("📤 正在发送通知: %s", article.Title)
-				if err := notifier.Send(webhookURL, article.Title, article.URL); err != nil {
-					log.Printf("❌ 飞书通知失败: %v", err)
-				} else {
-					log.Println("✅ 飞书通知发送成功")
-					// update article as notified
-					if err := storage.MarkAsNotified(article.ID); err != nil {
-						log.Printf("⚠️ 更新通知状态失败: %v", err)
-					}
-				}
+			preview, thumbnailURL := articlePreview(article.ID)
+			notifyArticle(notifiers, article, preview, thumbnailURL)
+
+			// avoid hitting rate limits (增加随机延迟，更像人类)
+			time.Sleep(time.Duration(1000+rand.Intn(2000)) * time.Millisecond)
+		}
+	}
+}
+
+// notifyArticle fans article out to every configured channel concurrently,
+// skipping any channel that already succeeded for this article on a
+// previous run so a retry doesn't double-notify. preview and thumbnailURL
+// come from articlePreview and are passed straight through to each
+// channel's Notify.
+func notifyArticle(notifiers []notifier.Notifier, article storage.Article, preview, thumbnailURL string) {
+	sent, err := storage.NotifiedChannels(article.ID)
+	if err != nil {
+		log.Printf("⚠️ 查询已通知渠道失败: %v", err)
+	}
 
-				// avoid hitting rate limits (增加随机延迟，更像人类)
-				time.Sleep(time.Duration(1000+rand.Intn(2000)) * time.Millisecond)
+	var wg sync.WaitGroup
+	for _, n := range notifiers {
+		if containsString(sent, n.Channel()) {
+			continue
+		}
+
+		n := n
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			log.Printf("📤 正在通过 %s 发送通知: %s", n.Channel(), article.Title)
+			if err := n.Notify(article.Title, article.URL, preview, thumbnailURL); err != nil {
+				log.Printf("❌ %s 通知失败: %v", n.Channel(), err)
+				return
+			}
+
+			log.Printf("✅ %s 通知发送成功", n.Channel())
+			if err := storage.MarkNotifiedOn(article.ID, n.Channel()); err != nil {
+				log.Printf("⚠️ 更新通知状态失败: %v", err)
 			}
+		}()
+	}
+	wg.Wait()
+}
+
+// articlePreview builds a short body snippet and thumbnail URL for
+// article's notification from its previously fetched detail (see
+// crawler.FetchArticleDetails), returning empty strings if no detail was
+// saved for it (e.g. the fetch failed or hasn't run).
+func articlePreview(articleID int64) (preview, thumbnailURL string) {
+	detail, ok, err := storage.GetArticleDetail(articleID)
+	if err != nil {
+		log.Printf("⚠️ 查询文章详情失败: %v", err)
+		return "", ""
+	}
+	if !ok {
+		return "", ""
+	}
+
+	const maxPreviewRunes = 120
+	runes := []rune(detail.Body)
+	if len(runes) > maxPreviewRunes {
+		preview = string(runes[:maxPreviewRunes]) + "..."
+	} else {
+		preview = detail.Body
+	}
+
+	if len(detail.Images) > 0 {
+		thumbnailURL = detail.Images[0]
+	}
+	return preview, thumbnailURL
+}
+
+// containsString reports whether values contains target.
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
 		}
 	}
+	return false
 }
 
 // setupLogging 配置日志输出到文件和控制台
@@ -234,6 +422,11 @@ func main() {
 	// 启动配置文件热加载监听
 	watchConfig(configFile)
 
+	// 启动 Feed 服务器，供 RSS/Atom/JSON Feed 阅读器订阅
+	if config.FeedServer.Enabled {
+		feedserver.Start(config.FeedServer.Addr)
+	}
+
 	log.Println("🚀 RoboMaster Monitor 启动成功")
 
 	// 立即运行一次